@@ -0,0 +1,314 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrContractCreation      = errors.New("failed to create game contract bindings")
+	ErrMetadataFetch         = errors.New("failed to fetch game metadata")
+	ErrClaimFetch            = errors.New("failed to fetch game claims")
+	ErrMaxClockDurationFetch = errors.New("failed to fetch game max clock duration")
+	ErrRootAgreement         = errors.New("failed to check root claim agreement")
+)
+
+const (
+	agreeDefenderAhead      = "agree_defender_ahead"
+	disagreeDefenderAhead   = "disagree_defender_ahead"
+	agreeChallengerAhead    = "agree_challenger_ahead"
+	disagreeChallengerAhead = "disagree_challenger_ahead"
+)
+
+// GameCaller reads the on-chain state of a single dispute game.
+type GameCaller interface {
+	// GetGameMetadata returns the game's current root claim and status.
+	GetGameMetadata(ctx context.Context) (common.Hash, types.GameStatus, error)
+	// GetAllClaims returns every claim posted to the game so far, in the order they were made.
+	GetAllClaims(ctx context.Context) ([]faultTypes.Claim, error)
+	// GetMaxClockDuration returns the per-side chess clock budget the game allows before that side
+	// forfeits for running out of time.
+	GetMaxClockDuration(ctx context.Context) (time.Duration, error)
+}
+
+// GameCallerCreator creates a GameCaller bound to a specific game's contract address.
+type GameCallerCreator interface {
+	CreateContract(ctx context.Context, game types.GameMetadata) (GameCaller, error)
+}
+
+// OutputValidator computes the root claim this node independently derives for a given game, to be
+// compared against the root claim actually posted on-chain for that game.
+type OutputValidator interface {
+	ExpectedRootClaim(ctx context.Context, game types.GameMetadata) (common.Hash, error)
+}
+
+// ForecastMetrics receives the aggregate classification of a batch of Forecast calls.
+type ForecastMetrics interface {
+	// RecordGameAgreement records, for the most recent Forecast call, how many games fell into status
+	// (one of agree_defender_ahead, disagree_defender_ahead, agree_challenger_ahead or
+	// disagree_challenger_ahead).
+	RecordGameAgreement(status string, count int)
+	// RecordBondForecast records the total bond value, in wei, projected to be lost by the losing side
+	// across all in-progress games currently forecast into status.
+	RecordBondForecast(status string, wei *big.Int)
+}
+
+// forecast projects the eventual outcome of in-progress dispute games from their current claim state.
+type forecast struct {
+	logger    log.Logger
+	metrics   ForecastMetrics
+	creator   GameCallerCreator
+	validator OutputValidator
+
+	// challenger, if set, is compared against each claim's claimant to flag when this node's own bonds
+	// are at risk on a game's projected-losing side.
+	challenger common.Address
+	// bondAtRiskWarnThreshold is the wei value of challenger-held bond on the losing side above which a
+	// warning is logged. A nil threshold disables the warning.
+	bondAtRiskWarnThreshold *big.Int
+}
+
+// ForecastOption configures optional behavior of a forecast.
+type ForecastOption func(*forecast)
+
+// WithChallengerAddress sets the address whose claims are checked against the configurable bond-at-risk
+// warning threshold.
+func WithChallengerAddress(addr common.Address) ForecastOption {
+	return func(f *forecast) { f.challenger = addr }
+}
+
+// WithBondAtRiskWarnThreshold sets the wei value of the challenger's own bond on a projected-losing side
+// above which Forecast logs a warning.
+func WithBondAtRiskWarnThreshold(wei *big.Int) ForecastOption {
+	return func(f *forecast) { f.bondAtRiskWarnThreshold = wei }
+}
+
+func newForecast(logger log.Logger, metrics ForecastMetrics, creator GameCallerCreator, validator OutputValidator, opts ...ForecastOption) *forecast {
+	f := &forecast{logger: logger, metrics: metrics, creator: creator, validator: validator}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Forecast projects the outcome of every game in games and records aggregate agreement and bond-at-risk
+// metrics for the batch.
+func (f *forecast) Forecast(ctx context.Context, games []types.GameMetadata) {
+	counts := map[string]int{
+		agreeDefenderAhead:      0,
+		disagreeDefenderAhead:   0,
+		agreeChallengerAhead:    0,
+		disagreeChallengerAhead: 0,
+	}
+	bondsAtRisk := map[string]*big.Int{
+		agreeDefenderAhead:      new(big.Int),
+		disagreeDefenderAhead:   new(big.Int),
+		agreeChallengerAhead:    new(big.Int),
+		disagreeChallengerAhead: new(big.Int),
+	}
+
+	for _, game := range games {
+		f.forecastGame(ctx, game, counts, bondsAtRisk)
+	}
+
+	for status, count := range counts {
+		f.metrics.RecordGameAgreement(status, count)
+		f.metrics.RecordBondForecast(status, bondsAtRisk[status])
+	}
+}
+
+func (f *forecast) forecastGame(ctx context.Context, game types.GameMetadata, counts map[string]int, bondsAtRisk map[string]*big.Int) {
+	caller, err := f.creator.CreateContract(ctx, game)
+	if err != nil {
+		f.logger.Error("Failed to forecast game", "err", fmt.Errorf("%w: %w", ErrContractCreation, err))
+		return
+	}
+
+	rootClaim, status, err := caller.GetGameMetadata(ctx)
+	if err != nil {
+		f.logger.Error("Failed to forecast game", "err", fmt.Errorf("%w: %w", ErrMetadataFetch, err))
+		return
+	}
+
+	if status != types.GameStatusInProgress {
+		f.logger.Debug("Game is not in progress, skipping forecast", "game", game, "status", status)
+		return
+	}
+
+	claims, err := caller.GetAllClaims(ctx)
+	if err != nil {
+		f.logger.Error("Failed to forecast game", "err", fmt.Errorf("%w: %w", ErrClaimFetch, err))
+		return
+	}
+
+	maxClockDuration, err := caller.GetMaxClockDuration(ctx)
+	if err != nil {
+		f.logger.Error("Failed to forecast game", "err", fmt.Errorf("%w: %w", ErrMaxClockDurationFetch, err))
+		return
+	}
+
+	expected, err := f.validator.ExpectedRootClaim(ctx, game)
+	if err != nil {
+		f.logger.Error("Failed to forecast game", "err", fmt.Errorf("%w: %w", ErrRootAgreement, err))
+		return
+	}
+
+	agree := rootClaim == expected
+	result := forecastResult(claims, maxClockDuration)
+	key := agreementKey(agree, result)
+
+	logArgs := []any{"rootClaim", rootClaim, "expected", expected, "status", result}
+	if expectedResult(agree, result) {
+		f.logger.Debug("Forecasting expected game result", logArgs...)
+	} else {
+		f.logger.Warn("Forecasting unexpected game result", logArgs...)
+	}
+
+	counts[key]++
+	atRisk := bondAtRisk(claims, result)
+	bondsAtRisk[key].Add(bondsAtRisk[key], atRisk)
+
+	f.checkChallengerBondAtRisk(game, claims, result)
+}
+
+// checkChallengerBondAtRisk logs a warning if f.challenger holds claims on the projected-losing side of
+// game whose combined bond exceeds f.bondAtRiskWarnThreshold.
+func (f *forecast) checkChallengerBondAtRisk(game types.GameMetadata, claims []faultTypes.Claim, result types.GameStatus) {
+	if f.bondAtRiskWarnThreshold == nil || f.challenger == (common.Address{}) {
+		return
+	}
+	ownBondAtRisk := challengerBondAtRisk(claims, result, f.challenger)
+	if ownBondAtRisk.Cmp(f.bondAtRiskWarnThreshold) > 0 {
+		f.logger.Warn("Challenger bond at risk on projected-losing side", "game", game, "status", result, "wei", ownBondAtRisk)
+	}
+}
+
+// forecastResult projects the final status of an in-progress game from its current claim list and the
+// game's max clock duration. It walks the claim tree via Position/ContractIndex/ParentContractIndex
+// (rather than trusting the order GetAllClaims happens to return, which includes abandoned branches left
+// behind whenever more than one challenger counters the same parent) to find the deepest claim on the
+// currently-live branch. Depth parity alone controls who's ahead: even depths (0, 2, 4, ...) are the
+// defender's root claim and its re-assertions, odd depths are challenger counter-claims.
+//
+// But depth parity alone only tells us who is ahead right now, not who wins: a side whose own chess clock
+// has already run out can never post another claim, so if the live leaf itself has exhausted
+// maxClockDuration, the other side will eventually force them out regardless of the current depth, and
+// the projection flips to the opposing side.
+func forecastResult(claims []faultTypes.Claim, maxClockDuration time.Duration) types.GameStatus {
+	if len(claims) == 0 {
+		return types.GameStatusChallengerWon
+	}
+	leaf := livePathLeaf(claims)
+	parity := forecastResultByDepth(leaf.Position.Depth())
+	if leaf.Clock.Duration() >= maxClockDuration {
+		if parity == types.GameStatusDefenderWon {
+			return types.GameStatusChallengerWon
+		}
+		return types.GameStatusDefenderWon
+	}
+	return parity
+}
+
+// livePathLeaf walks claims via ParentContractIndex/ContractIndex, starting from the depth-0 root, to
+// find the deepest claim on the currently-live contested branch. See forecastResult.
+func livePathLeaf(claims []faultTypes.Claim) faultTypes.Claim {
+	childrenByParent := make(map[int][]faultTypes.Claim, len(claims))
+	var root faultTypes.Claim
+	for _, claim := range claims {
+		if claim.Position.Depth() == 0 {
+			root = claim
+			continue
+		}
+		childrenByParent[claim.ParentContractIndex] = append(childrenByParent[claim.ParentContractIndex], claim)
+	}
+	return deepestLiveDescendant(root, childrenByParent)
+}
+
+// deepestLiveDescendant follows, at each position, the counter-claim with the highest ContractIndex:
+// earlier counters to the same parent have been superseded and are no longer part of the live path the
+// game would resolve from if no further moves were made.
+func deepestLiveDescendant(current faultTypes.Claim, childrenByParent map[int][]faultTypes.Claim) faultTypes.Claim {
+	children := childrenByParent[current.ContractIndex]
+	if len(children) == 0 {
+		return current
+	}
+	latest := children[0]
+	for _, c := range children[1:] {
+		if c.ContractIndex > latest.ContractIndex {
+			latest = c
+		}
+	}
+	return deepestLiveDescendant(latest, childrenByParent)
+}
+
+// forecastResultByDepth projects the final status of an in-progress game from the live leaf's depth
+// alone, with no regard for remaining clock time. See forecastResult.
+func forecastResultByDepth(depth uint64) types.GameStatus {
+	if depth%2 == 0 {
+		return types.GameStatusDefenderWon
+	}
+	return types.GameStatusChallengerWon
+}
+
+// expectedResult reports whether the projected result is the "healthy" outcome: the side whose claim
+// matches this node's independently derived output should be the side that ends up winning.
+func expectedResult(agree bool, result types.GameStatus) bool {
+	return (agree && result == types.GameStatusDefenderWon) || (!agree && result == types.GameStatusChallengerWon)
+}
+
+func agreementKey(agree bool, result types.GameStatus) string {
+	switch {
+	case agree && result == types.GameStatusDefenderWon:
+		return agreeDefenderAhead
+	case !agree && result == types.GameStatusDefenderWon:
+		return disagreeDefenderAhead
+	case agree && result == types.GameStatusChallengerWon:
+		return agreeChallengerAhead
+	default:
+		return disagreeChallengerAhead
+	}
+}
+
+// bondAtRisk sums the bonds posted by claims on the side of the claim tree that is projected to lose if
+// the game resolves with the given result. Every claim is considered, not just those on the live path:
+// an abandoned branch's claimant still posted a real bond, and still loses it if their side loses.
+func bondAtRisk(claims []faultTypes.Claim, result types.GameStatus) *big.Int {
+	total := new(big.Int)
+	for _, claim := range claims {
+		if claim.Bond == nil || !onLosingSide(claim.Position.Depth(), result) {
+			continue
+		}
+		total.Add(total, claim.Bond)
+	}
+	return total
+}
+
+// challengerBondAtRisk is like bondAtRisk, but sums only the bonds of claims made by challenger.
+func challengerBondAtRisk(claims []faultTypes.Claim, result types.GameStatus, challenger common.Address) *big.Int {
+	total := new(big.Int)
+	for _, claim := range claims {
+		if claim.Bond == nil || claim.Claimant != challenger || !onLosingSide(claim.Position.Depth(), result) {
+			continue
+		}
+		total.Add(total, claim.Bond)
+	}
+	return total
+}
+
+// onLosingSide reports whether a claim at the given depth sits on the side of the claim tree that loses
+// if the game resolves with the given result.
+func onLosingSide(depth uint64, result types.GameStatus) bool {
+	isDefenderClaim := depth%2 == 0
+	if result == types.GameStatusChallengerWon {
+		return isDefenderClaim
+	}
+	return !isDefenderClaim
+}
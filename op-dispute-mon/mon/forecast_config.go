@@ -0,0 +1,67 @@
+package mon
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ChallengerAddressFlagName       = "challenger-address"
+	BondAtRiskWarnThresholdFlagName = "bond-at-risk-warn-threshold"
+)
+
+// ForecastFlags returns the CLI flags used to configure the challenger bond-at-risk warning on
+// op-dispute-mon.
+func ForecastFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    ChallengerAddressFlagName,
+			Usage:   "Address whose claims are checked against bond-at-risk-warn-threshold on a game's projected-losing side",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "CHALLENGER_ADDRESS"),
+		},
+		&cli.StringFlag{
+			Name:    BondAtRiskWarnThresholdFlagName,
+			Usage:   "Wei value of challenger-address's own bond on a projected-losing side above which a warning is logged. Disabled if unset; zero warns on any bond at risk",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "BOND_AT_RISK_WARN_THRESHOLD"),
+		},
+	}
+}
+
+// ForecastConfig is the parsed result of ForecastFlags.
+type ForecastConfig struct {
+	ChallengerAddress       common.Address
+	BondAtRiskWarnThreshold *big.Int
+}
+
+func NewForecastConfig(ctx *cli.Context) (*ForecastConfig, error) {
+	cfg := &ForecastConfig{
+		ChallengerAddress: common.HexToAddress(ctx.String(ChallengerAddressFlagName)),
+	}
+	if raw := ctx.String(BondAtRiskWarnThresholdFlagName); raw != "" {
+		threshold, ok := new(big.Int).SetString(raw, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: %q is not a base-10 integer", BondAtRiskWarnThresholdFlagName, raw)
+		}
+		cfg.BondAtRiskWarnThreshold = threshold
+	}
+	return cfg, nil
+}
+
+// Options returns the ForecastOptions that apply cfg to a forecast. op-dispute-mon's own cmd-level wiring
+// (constructing the forecast with these options appended to newForecast's call) lives outside this
+// source tree and is not part of this change.
+func (c *ForecastConfig) Options() []ForecastOption {
+	var opts []ForecastOption
+	if c.ChallengerAddress != (common.Address{}) {
+		opts = append(opts, WithChallengerAddress(c.ChallengerAddress))
+	}
+	if c.BondAtRiskWarnThreshold != nil {
+		opts = append(opts, WithBondAtRiskWarnThreshold(c.BondAtRiskWarnThreshold))
+	}
+	return opts
+}
@@ -0,0 +1,34 @@
+package mon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastConfig_Options(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		cfg := &ForecastConfig{}
+		f := &forecast{}
+		for _, opt := range cfg.Options() {
+			opt(f)
+		}
+		require.Equal(t, common.Address{}, f.challenger)
+		require.Nil(t, f.bondAtRiskWarnThreshold)
+	})
+
+	t.Run("ChallengerAndThresholdSet", func(t *testing.T) {
+		cfg := &ForecastConfig{
+			ChallengerAddress:       mockChallenger,
+			BondAtRiskWarnThreshold: big.NewInt(1_000),
+		}
+		f := &forecast{}
+		for _, opt := range cfg.Options() {
+			opt(f)
+		}
+		require.Equal(t, mockChallenger, f.challenger)
+		require.Zero(t, big.NewInt(1_000).Cmp(f.bondAtRiskWarnThreshold))
+	})
+}
@@ -0,0 +1,136 @@
+package mon
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var mockRootClaim = common.HexToHash("0x1234")
+
+// mockChallenger is the address used by tests that exercise the challenger bond-at-risk warning.
+var mockChallenger = common.HexToAddress("0x5678")
+
+// defaultClaimBond is the bond assigned to each fixture claim that doesn't need a specific value.
+var defaultClaimBond = big.NewInt(1_000000000_000000000) // 1 ETH
+
+// defaultMaxClockDuration is the per-side chess clock budget used by fixture games, matching the 3.5-day
+// default used by deployed fault dispute games.
+const defaultMaxClockDuration = 3*24*time.Hour + 12*time.Hour
+
+// createDeepClaimList returns a straight chain of claims alternating between the defender's root claim
+// (even depths) and the challenger's counter-claims (odd depths), each countering the one before it, and
+// suitable for slicing to the desired depth with e.g. createDeepClaimList()[:2]. Every claim's clock is
+// far short of defaultMaxClockDuration, so none of them are expired unless a test overrides Clock
+// explicitly.
+func createDeepClaimList() []faultTypes.Claim {
+	claims := make([]faultTypes.Claim, 0, 4)
+	for depth := 0; depth < 4; depth++ {
+		claims = append(claims, newFixtureClaim(depth, depth, depth-1))
+	}
+	return claims
+}
+
+// createBranchingClaimList returns a claim tree where a second challenger counters the same depth-1 claim
+// already countered by createDeepClaimList, posting after (and so superseding) the first counter. Both
+// depth-2 claims share ParentContractIndex 1, but only the higher-ContractIndex one is on the live path.
+func createBranchingClaimList() []faultTypes.Claim {
+	return []faultTypes.Claim{
+		newFixtureClaim(0, 0, -1),
+		newFixtureClaim(1, 1, 0),
+		newFixtureClaim(2, 2, 1), // abandoned: superseded by the claim below
+		newFixtureClaim(2, 3, 1), // the live counter to the depth-1 claim
+	}
+}
+
+// newFixtureClaim builds a claim at the given tree depth and contract index, countering parentIndex.
+func newFixtureClaim(depth, contractIndex, parentIndex int) faultTypes.Claim {
+	return faultTypes.Claim{
+		Bond:                new(big.Int).Set(defaultClaimBond),
+		Clock:               faultTypes.NewClock(time.Hour, time.Time{}),
+		Position:            faultTypes.NewPosition(depth, big.NewInt(0)),
+		ContractIndex:       contractIndex,
+		ParentContractIndex: parentIndex,
+	}
+}
+
+// mockGameCaller is a test double for GameCaller whose responses are driven off per-game-type slices,
+// indexed by the number of times the corresponding method has previously been called.
+type mockGameCaller struct {
+	calls     int
+	err       error
+	rootClaim []common.Hash
+	status    []types.GameStatus
+
+	claimsCalls int
+	claimsErr   error
+	claims      [][]faultTypes.Claim
+
+	maxClockDurationCalls int
+	maxClockDurationErr   error
+	maxClockDuration      time.Duration
+}
+
+func (m *mockGameCaller) GetGameMetadata(ctx context.Context) (common.Hash, types.GameStatus, error) {
+	idx := m.calls
+	m.calls++
+	if m.err != nil {
+		return common.Hash{}, types.GameStatusInProgress, m.err
+	}
+	return m.rootClaim[idx], m.status[idx], nil
+}
+
+func (m *mockGameCaller) GetAllClaims(ctx context.Context) ([]faultTypes.Claim, error) {
+	idx := m.claimsCalls
+	m.claimsCalls++
+	if m.claimsErr != nil {
+		return nil, m.claimsErr
+	}
+	return m.claims[idx], nil
+}
+
+// GetMaxClockDuration returns m.maxClockDuration, defaulting to defaultMaxClockDuration when unset so
+// that tests which don't care about clock expiry don't need to set it explicitly.
+func (m *mockGameCaller) GetMaxClockDuration(ctx context.Context) (time.Duration, error) {
+	m.maxClockDurationCalls++
+	if m.maxClockDurationErr != nil {
+		return 0, m.maxClockDurationErr
+	}
+	if m.maxClockDuration == 0 {
+		return defaultMaxClockDuration, nil
+	}
+	return m.maxClockDuration, nil
+}
+
+// mockGameCallerCreator is a test double for GameCallerCreator that always returns the same caller.
+type mockGameCallerCreator struct {
+	calls  int
+	err    error
+	caller *mockGameCaller
+}
+
+func (m *mockGameCallerCreator) CreateContract(ctx context.Context, game types.GameMetadata) (GameCaller, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.caller, nil
+}
+
+// stubOutputValidator is a test double for OutputValidator that returns mockRootClaim unless err is set.
+type stubOutputValidator struct {
+	calls int
+	err   error
+}
+
+func (s *stubOutputValidator) ExpectedRootClaim(ctx context.Context, game types.GameMetadata) (common.Hash, error) {
+	s.calls++
+	if s.err != nil {
+		return common.Hash{}, s.err
+	}
+	return mockRootClaim, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -30,6 +32,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 0, creator.calls)
 		require.Equal(t, 0, creator.caller.calls)
 		require.Equal(t, 0, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 0, rollup.calls)
 		require.Nil(t, logs.FindLog(log.LevelError, expectedForecastLog))
 	})
@@ -41,6 +44,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 0, creator.caller.calls)
 		require.Equal(t, 0, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 0, rollup.calls)
 		l := logs.FindLog(log.LevelError, expectedForecastLog)
 		require.NotNil(t, l)
@@ -58,6 +62,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 1, creator.caller.calls)
 		require.Equal(t, 0, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 0, rollup.calls)
 		l := logs.FindLog(log.LevelError, expectedForecastLog)
 		require.NotNil(t, l)
@@ -75,6 +80,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 1, creator.caller.calls)
 		require.Equal(t, 1, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 0, rollup.calls)
 		l := logs.FindLog(log.LevelError, expectedForecastLog)
 		require.NotNil(t, l)
@@ -83,6 +89,25 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, expectedErr, err)
 	})
 
+	t.Run("MaxClockDurationFetchFails", func(t *testing.T) {
+		forecast, _, creator, rollup, logs := setupForecastTest(t)
+		creator.caller.maxClockDurationErr = errors.New("boom")
+		creator.caller.claims = [][]faultTypes.Claim{{{}}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		creator.caller.status = []types.GameStatus{types.GameStatusInProgress}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		require.Equal(t, 1, creator.calls)
+		require.Equal(t, 1, creator.caller.calls)
+		require.Equal(t, 1, creator.caller.claimsCalls)
+		require.Equal(t, 1, creator.caller.maxClockDurationCalls)
+		require.Equal(t, 0, rollup.calls)
+		l := logs.FindLog(log.LevelError, expectedForecastLog)
+		require.NotNil(t, l)
+		err := l.AttrValue("err")
+		expectedErr := fmt.Errorf("%w: %w", ErrMaxClockDurationFetch, creator.caller.maxClockDurationErr)
+		require.Equal(t, expectedErr, err)
+	})
+
 	t.Run("RollupFetchFails", func(t *testing.T) {
 		forecast, _, creator, rollup, logs := setupForecastTest(t)
 		rollup.err = errors.New("boom")
@@ -93,6 +118,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 1, creator.caller.calls)
 		require.Equal(t, 1, creator.caller.claimsCalls)
+		require.Equal(t, 1, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 1, rollup.calls)
 		l := logs.FindLog(log.LevelError, expectedForecastLog)
 		require.NotNil(t, l)
@@ -111,6 +137,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 1, creator.caller.calls)
 		require.Equal(t, 0, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 0, rollup.calls)
 		require.Nil(t, logs.FindLog(log.LevelError, expectedForecastLog))
 		l := logs.FindLog(log.LevelDebug, expectedInProgressLog)
@@ -129,6 +156,7 @@ func TestForecast_Forecast_BasicTests(t *testing.T) {
 		require.Equal(t, 1, creator.calls)
 		require.Equal(t, 1, creator.caller.calls)
 		require.Equal(t, 0, creator.caller.claimsCalls)
+		require.Equal(t, 0, creator.caller.maxClockDurationCalls)
 		require.Equal(t, 0, rollup.calls)
 		require.Nil(t, logs.FindLog(log.LevelError, expectedForecastLog))
 		l := logs.FindLog(log.LevelDebug, expectedInProgressLog)
@@ -218,6 +246,145 @@ func TestForecast_Forecast_EndLogs(t *testing.T) {
 	})
 }
 
+func TestForecast_Forecast_BondAtRisk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DefenderWonBondsOnChallengerSideAtRisk", func(t *testing.T) {
+		forecast, metrics, creator, _, _ := setupForecastTest(t)
+		creator.caller = &mockGameCaller{status: []types.GameStatus{types.GameStatusInProgress}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		// depths 0, 1, 2: an odd-length chain (DefenderWon) with the depth-1 challenger counter-claim on
+		// the losing side.
+		creator.caller.claims = [][]faultTypes.Claim{createDeepClaimList()[:3]}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		require.Zero(t, defaultClaimBond.Cmp(metrics.bondsAtRisk[agreeDefenderAhead]))
+		require.Zero(t, big.NewInt(0).Cmp(metrics.bondsAtRisk[agreeChallengerAhead]))
+	})
+
+	t.Run("ChallengerWonBondsOnDefenderSideAtRisk", func(t *testing.T) {
+		forecast, metrics, creator, _, _ := setupForecastTest(t)
+		creator.caller = &mockGameCaller{status: []types.GameStatus{types.GameStatusInProgress}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		creator.caller.claims = [][]faultTypes.Claim{createDeepClaimList()[:2]}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		require.Zero(t, defaultClaimBond.Cmp(metrics.bondsAtRisk[agreeChallengerAhead]))
+		require.Zero(t, big.NewInt(0).Cmp(metrics.bondsAtRisk[agreeDefenderAhead]))
+	})
+}
+
+func TestForecast_Forecast_ClockExpiry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ExpiredLeaderLosesDespiteParity", func(t *testing.T) {
+		forecast, _, creator, _, logs := setupForecastTest(t)
+		creator.caller = &mockGameCaller{status: []types.GameStatus{types.GameStatusInProgress}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		// depths 0, 1: parity alone (an even-length chain) projects ChallengerWon, but the challenger's
+		// own depth-1 claim has already burned through its entire clock budget, so the projection flips
+		// to DefenderWon, which is the expected result for an agreed root claim.
+		claims := createDeepClaimList()[:2]
+		claims[1].Clock = faultTypes.NewClock(defaultMaxClockDuration, time.Time{})
+		creator.caller.claims = [][]faultTypes.Claim{claims}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		l := logs.FindLog(log.LevelDebug, expectedResultLog)
+		require.NotNil(t, l)
+		require.Equal(t, types.GameStatusDefenderWon, l.AttrValue("status"))
+	})
+
+	t.Run("UnexpiredResponderDoesNotFlipResult", func(t *testing.T) {
+		forecast, _, creator, _, logs := setupForecastTest(t)
+		creator.caller = &mockGameCaller{status: []types.GameStatus{types.GameStatusInProgress}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		// The depth-0 defender is already trailing by parity, so its own clock running out changes
+		// nothing: only the projected leader's (the challenger's) clock determines whether the
+		// projection flips, and it still has time, so parity's ChallengerWon projection stands.
+		claims := createDeepClaimList()[:2]
+		claims[0].Clock = faultTypes.NewClock(defaultMaxClockDuration, time.Time{})
+		creator.caller.claims = [][]faultTypes.Claim{claims}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		l := logs.FindLog(log.LevelWarn, unexpectedResultLog)
+		require.NotNil(t, l)
+		require.Equal(t, types.GameStatusChallengerWon, l.AttrValue("status"))
+	})
+}
+
+func TestForecast_Forecast_BranchingTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FollowsLiveCounterNotArrayOrder", func(t *testing.T) {
+		forecast, _, creator, _, logs := setupForecastTest(t)
+		creator.caller = &mockGameCaller{status: []types.GameStatus{types.GameStatusInProgress}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		// Two challengers counter the same depth-1 claim; GetAllClaims returns the abandoned
+		// ContractIndex-2 branch before the live ContractIndex-3 one. Projecting from the 4-claim slice's
+		// length would (wrongly) favor the challenger; walking the tree by position correctly follows the
+		// live leaf to its depth-2, defender-favoring result.
+		creator.caller.claims = [][]faultTypes.Claim{createBranchingClaimList()}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		l := logs.FindLog(log.LevelDebug, expectedResultLog)
+		require.NotNil(t, l)
+		require.Equal(t, types.GameStatusDefenderWon, l.AttrValue("status"))
+	})
+}
+
+func TestForecast_Forecast_ChallengerBondAtRiskWarning(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LogsWarnWhenOwnBondAboveThreshold", func(t *testing.T) {
+		logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
+		validator := &stubOutputValidator{}
+		caller := &mockGameCaller{rootClaim: []common.Hash{mockRootClaim}}
+		creator := &mockGameCallerCreator{caller: caller}
+		metrics := &mockForecastMetrics{}
+		// depths 0, 1: an even-length chain (ChallengerWon), with the challenger's own claim on the
+		// losing, depth-0 defender side.
+		claims := createDeepClaimList()[:2]
+		claims[0].Claimant = mockChallenger
+		caller.claims = [][]faultTypes.Claim{claims}
+		caller.status = []types.GameStatus{types.GameStatusInProgress}
+
+		forecast := newForecast(logger, metrics, creator, validator,
+			WithChallengerAddress(mockChallenger),
+			WithBondAtRiskWarnThreshold(big.NewInt(0)))
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+
+		l := capturedLogs.FindLog(log.LevelWarn, "Challenger bond at risk on projected-losing side")
+		require.NotNil(t, l)
+		require.Equal(t, types.GameStatusChallengerWon, l.AttrValue("status"))
+		require.Zero(t, defaultClaimBond.Cmp(l.AttrValue("wei").(*big.Int)))
+	})
+
+	t.Run("NoWarnWhenBelowThreshold", func(t *testing.T) {
+		logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
+		validator := &stubOutputValidator{}
+		caller := &mockGameCaller{rootClaim: []common.Hash{mockRootClaim}}
+		creator := &mockGameCallerCreator{caller: caller}
+		metrics := &mockForecastMetrics{}
+		claims := createDeepClaimList()[:2]
+		claims[0].Claimant = mockChallenger
+		caller.claims = [][]faultTypes.Claim{claims}
+		caller.status = []types.GameStatus{types.GameStatusInProgress}
+
+		forecast := newForecast(logger, metrics, creator, validator,
+			WithChallengerAddress(mockChallenger),
+			WithBondAtRiskWarnThreshold(new(big.Int).Mul(defaultClaimBond, big.NewInt(2))))
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+
+		require.Nil(t, capturedLogs.FindLog(log.LevelWarn, "Challenger bond at risk on projected-losing side"))
+	})
+
+	t.Run("NoWarnWhenThresholdUnset", func(t *testing.T) {
+		forecast, _, creator, _, logs := setupForecastTest(t)
+		creator.caller = &mockGameCaller{status: []types.GameStatus{types.GameStatusInProgress}}
+		creator.caller.rootClaim = []common.Hash{mockRootClaim}
+		claims := createDeepClaimList()[:2]
+		claims[1].Claimant = mockChallenger
+		creator.caller.claims = [][]faultTypes.Claim{claims}
+		forecast.Forecast(context.Background(), []types.GameMetadata{{}})
+		require.Nil(t, logs.FindLog(log.LevelWarn, "Challenger bond at risk on projected-losing side"))
+	})
+}
+
 func TestForecast_Forecast_MultipleGames(t *testing.T) {
 	forecast, _, creator, rollup, logs := setupForecastTest(t)
 	creator.caller.status = []types.GameStatus{
@@ -273,6 +440,8 @@ type mockForecastMetrics struct {
 	disagreeDefenderAhead   int
 	agreeChallengerAhead    int
 	disagreeChallengerAhead int
+
+	bondsAtRisk map[string]*big.Int
 }
 
 func (m *mockForecastMetrics) RecordGameAgreement(status string, count int) {
@@ -287,3 +456,10 @@ func (m *mockForecastMetrics) RecordGameAgreement(status string, count int) {
 		m.disagreeChallengerAhead = count
 	}
 }
+
+func (m *mockForecastMetrics) RecordBondForecast(status string, wei *big.Int) {
+	if m.bondsAtRisk == nil {
+		m.bondsAtRisk = make(map[string]*big.Int)
+	}
+	m.bondsAtRisk[status] = wei
+}
@@ -0,0 +1,284 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// EndpointTag hints at the kind of data a beacon endpoint can be expected to serve. It is used by
+// BeaconClientPool to prefer endpoints that are likely to actually hold the requested data.
+type EndpointTag string
+
+const (
+	// EndpointTagRecent marks an endpoint that only retains blobs for the minimum required retention
+	// window (e.g. a regular beacon node pruning at the data-availability horizon).
+	EndpointTagRecent EndpointTag = "recent"
+	// EndpointTagArchive marks an endpoint that retains blobs indefinitely (e.g. a blob archiver).
+	EndpointTagArchive EndpointTag = "archive"
+)
+
+// breakerState is the state of a single endpoint's circuit breaker.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// breakerWindowSize is the number of most recent calls used to compute an endpoint's rolling error rate.
+	breakerWindowSize = 20
+	// breakerErrorThreshold is the fraction of calls in the window that must fail before the breaker opens.
+	breakerErrorThreshold = 0.5
+	// breakerMinSamples is the minimum number of calls in the window before the breaker is allowed to open.
+	breakerMinSamples = 5
+	// breakerBaseCooldown is the initial cooldown duration applied the first time a breaker opens.
+	breakerBaseCooldown = time.Second
+	// breakerMaxCooldown caps the exponential backoff applied to repeatedly failing endpoints.
+	breakerMaxCooldown = 2 * time.Minute
+)
+
+// BeaconClientPoolMetrics is implemented by the metrics collector passed to NewBeaconClientPool.
+type BeaconClientPoolMetrics interface {
+	RecordBeaconClientRequest(endpoint string, method string, duration time.Duration, err error)
+	RecordBeaconClientBreakerState(endpoint string, state string)
+}
+
+// NoopBeaconClientPoolMetrics is a BeaconClientPoolMetrics that does nothing, for callers that don't
+// want per-endpoint metrics.
+type NoopBeaconClientPoolMetrics struct{}
+
+func (NoopBeaconClientPoolMetrics) RecordBeaconClientRequest(string, string, time.Duration, error) {}
+func (NoopBeaconClientPoolMetrics) RecordBeaconClientBreakerState(string, string)                  {}
+
+// poolEndpoint wraps a single BeaconClient with circuit-breaker bookkeeping.
+type poolEndpoint struct {
+	name   string
+	tag    EndpointTag
+	client BeaconClient
+
+	mu            sync.Mutex
+	state         breakerState
+	results       [breakerWindowSize]bool // true = success
+	resultCount   int
+	nextIdx       int
+	openedAt      time.Time
+	cooldown      time.Duration
+	probeInFlight bool // true while a half-open endpoint's single probe call hasn't resolved yet
+}
+
+func newPoolEndpoint(name string, tag EndpointTag, client BeaconClient) *poolEndpoint {
+	return &poolEndpoint{name: name, tag: tag, client: client, state: breakerClosed}
+}
+
+// available reports whether the endpoint may currently be used. It also performs the open -> half-open
+// transition once the cooldown window has elapsed. A half-open endpoint only ever grants a single probe
+// call: the caller that wins the transition (or that finds probeInFlight not yet claimed) is the only
+// one that gets true until recordResult resolves the probe.
+func (e *poolEndpoint) available(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch e.state {
+	case breakerClosed:
+		return true
+	case breakerHalfOpen:
+		if e.probeInFlight {
+			return false
+		}
+		e.probeInFlight = true
+		return true
+	case breakerOpen:
+		if now.Sub(e.openedAt) >= e.cooldown {
+			e.state = breakerHalfOpen
+			e.probeInFlight = true
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// recordResult feeds the outcome of a call into the rolling window and updates the breaker state,
+// returning the new state so the caller can emit metrics/logs.
+func (e *poolEndpoint) recordResult(success bool) breakerState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state == breakerHalfOpen {
+		// A half-open endpoint only ever takes a single probe call: success closes the breaker and
+		// resets its history, failure re-opens it with a longer cooldown.
+		e.probeInFlight = false
+		if success {
+			e.state = breakerClosed
+			e.resultCount = 0
+			e.nextIdx = 0
+			e.cooldown = 0
+		} else {
+			e.open()
+		}
+		return e.state
+	}
+
+	e.results[e.nextIdx] = success
+	e.nextIdx = (e.nextIdx + 1) % breakerWindowSize
+	if e.resultCount < breakerWindowSize {
+		e.resultCount++
+	}
+
+	if e.state == breakerClosed && e.resultCount >= breakerMinSamples && e.errorRate() > breakerErrorThreshold {
+		e.open()
+	}
+	return e.state
+}
+
+// errorRate must be called with e.mu held.
+func (e *poolEndpoint) errorRate() float64 {
+	if e.resultCount == 0 {
+		return 0
+	}
+	failures := 0
+	for i := 0; i < e.resultCount; i++ {
+		if !e.results[i] {
+			failures++
+		}
+	}
+	return float64(failures) / float64(e.resultCount)
+}
+
+// open must be called with e.mu held. It transitions the breaker to open and doubles the cooldown
+// applied the previous time it opened, up to breakerMaxCooldown.
+func (e *poolEndpoint) open() {
+	e.state = breakerOpen
+	e.openedAt = time.Now()
+	if e.cooldown == 0 {
+		e.cooldown = breakerBaseCooldown
+	} else {
+		e.cooldown *= 2
+		if e.cooldown > breakerMaxCooldown {
+			e.cooldown = breakerMaxCooldown
+		}
+	}
+}
+
+// BeaconClientPoolEndpoint describes a single endpoint to add to a BeaconClientPool.
+type BeaconClientPoolEndpoint struct {
+	Name   string
+	Tag    EndpointTag
+	Client BeaconClient
+}
+
+// BeaconClientPool is a BeaconClient that fans requests out to an ordered list of underlying
+// endpoints, skipping any endpoint whose circuit breaker is currently open, and preferring archive
+// endpoints for requests that fall outside the chain's data-retention horizon.
+type BeaconClientPool struct {
+	log log.Logger
+
+	endpoints []*poolEndpoint
+	metrics   BeaconClientPoolMetrics
+
+	// retentionHorizon reports whether the given slot is older than the oldest slot that non-archive
+	// endpoints can be expected to still serve. It is a function so tests can control it deterministically.
+	retentionHorizon func(slot uint64) bool
+}
+
+func NewBeaconClientPool(l log.Logger, metrics BeaconClientPoolMetrics, retentionHorizon func(slot uint64) bool, endpoints ...BeaconClientPoolEndpoint) *BeaconClientPool {
+	if metrics == nil {
+		metrics = NoopBeaconClientPoolMetrics{}
+	}
+	pool := &BeaconClientPool{log: l, metrics: metrics, retentionHorizon: retentionHorizon}
+	for _, ep := range endpoints {
+		pool.endpoints = append(pool.endpoints, newPoolEndpoint(ep.Name, ep.Tag, ep.Client))
+	}
+	return pool
+}
+
+// order returns the endpoints to try, in priority order, for a call regarding the given slot.
+// All endpoints are tried in configured order, with no archive preference, when slot is nil or no
+// retentionHorizon is set.
+func (p *BeaconClientPool) order(slot *uint64) []*poolEndpoint {
+	if slot == nil || p.retentionHorizon == nil || !p.retentionHorizon(*slot) {
+		return p.endpoints
+	}
+	// The requested slot is older than the retention horizon: archive endpoints are the only ones with
+	// a chance of a hit, so try them first to avoid a guaranteed miss against a recent-data node.
+	ordered := make([]*poolEndpoint, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		if ep.tag == EndpointTagArchive {
+			ordered = append(ordered, ep)
+		}
+	}
+	for _, ep := range p.endpoints {
+		if ep.tag != EndpointTagArchive {
+			ordered = append(ordered, ep)
+		}
+	}
+	return ordered
+}
+
+// call runs fn against the pool's endpoints, in priority order, skipping endpoints whose breaker is
+// open and returning the first success. method and slot are used only for metrics/endpoint ordering.
+func call[T any](p *BeaconClientPool, ctx context.Context, method string, slot *uint64, fn func(BeaconClient) (T, error)) (T, error) {
+	var zero T
+	now := time.Now()
+	var lastErr error
+	tried := 0
+	for _, ep := range p.order(slot) {
+		if !ep.available(now) {
+			continue
+		}
+		tried++
+
+		start := time.Now()
+		res, err := fn(ep.client)
+		duration := time.Since(start)
+
+		p.metrics.RecordBeaconClientRequest(ep.name, method, duration, err)
+		newState := ep.recordResult(err == nil)
+		p.metrics.RecordBeaconClientBreakerState(ep.name, newState.String())
+		if newState != breakerClosed {
+			p.log.Warn("beacon client pool endpoint breaker state changed", "endpoint", ep.name, "state", newState, "err", err)
+		}
+
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+		p.log.Warn("beacon client pool endpoint request failed, trying next endpoint", "endpoint", ep.name, "method", method, "err", err)
+	}
+	if tried == 0 {
+		return zero, fmt.Errorf("beacon client pool: no available endpoints for %s", method)
+	}
+	return zero, fmt.Errorf("beacon client pool: all %d attempted endpoints failed for %s: %w", tried, method, lastErr)
+}
+
+func (p *BeaconClientPool) NodeVersion(ctx context.Context) (string, error) {
+	return call(p, ctx, "NodeVersion", nil, func(c BeaconClient) (string, error) {
+		return c.NodeVersion(ctx)
+	})
+}
+
+func (p *BeaconClientPool) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	return call(p, ctx, "BeaconBlobSideCars", &slot, func(c BeaconClient) (eth.APIGetBlobSidecarsResponse, error) {
+		return c.BeaconBlobSideCars(ctx, fetchAllSidecars, slot, hashes)
+	})
+}
@@ -0,0 +1,62 @@
+package sources
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusBeaconClientPoolMetrics is the default BeaconClientPoolMetrics implementation, recording
+// per-endpoint call counts, error counts, latency and circuit-breaker state to Prometheus.
+type PrometheusBeaconClientPoolMetrics struct {
+	calls         *prometheus.CounterVec
+	errors        *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	breakerStates *prometheus.GaugeVec
+}
+
+func NewPrometheusBeaconClientPoolMetrics(ns string, registry *prometheus.Registry) *PrometheusBeaconClientPoolMetrics {
+	factory := promauto.With(prometheus.WrapRegistererWithPrefix(ns+"_", registry))
+	m := &PrometheusBeaconClientPoolMetrics{
+		calls: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "beacon_pool_calls_total",
+			Help: "Number of calls made to each beacon client pool endpoint",
+		}, []string{"endpoint", "method"}),
+		errors: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "beacon_pool_errors_total",
+			Help: "Number of failed calls made to each beacon client pool endpoint",
+		}, []string{"endpoint", "method"}),
+		latency: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "beacon_pool_request_duration_seconds",
+			Help:    "Latency of calls made to each beacon client pool endpoint",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint", "method"}),
+		breakerStates: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "beacon_pool_breaker_state",
+			Help: "Current circuit-breaker state of each beacon client pool endpoint (0=closed, 1=half-open, 2=open)",
+		}, []string{"endpoint"}),
+	}
+	return m
+}
+
+func (m *PrometheusBeaconClientPoolMetrics) RecordBeaconClientRequest(endpoint string, method string, duration time.Duration, err error) {
+	m.calls.WithLabelValues(endpoint, method).Inc()
+	m.latency.WithLabelValues(endpoint, method).Observe(duration.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(endpoint, method).Inc()
+	}
+}
+
+func (m *PrometheusBeaconClientPoolMetrics) RecordBeaconClientBreakerState(endpoint string, state string) {
+	var v float64
+	switch state {
+	case breakerClosed.String():
+		v = 0
+	case breakerHalfOpen.String():
+		v = 1
+	case breakerOpen.String():
+		v = 2
+	}
+	m.breakerStates.WithLabelValues(endpoint).Set(v)
+}
@@ -0,0 +1,155 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/mocks"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestBeaconClientPool_PrefersFirstHealthyEndpoint(t *testing.T) {
+	ctx := context.Background()
+	a := mocks.NewBeaconClient(t)
+	b := mocks.NewBeaconClient(t)
+
+	pool := NewBeaconClientPool(testlog.Logger(t, slog.LevelError), nil, nil,
+		BeaconClientPoolEndpoint{Name: "a", Tag: EndpointTagRecent, Client: a},
+		BeaconClientPoolEndpoint{Name: "b", Tag: EndpointTagRecent, Client: b},
+	)
+
+	a.EXPECT().NodeVersion(ctx).Once().Return("a-version", nil)
+	out, err := pool.NodeVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "a-version", out)
+}
+
+func TestBeaconClientPool_FallsThroughOnError(t *testing.T) {
+	ctx := context.Background()
+	a := mocks.NewBeaconClient(t)
+	b := mocks.NewBeaconClient(t)
+
+	pool := NewBeaconClientPool(testlog.Logger(t, slog.LevelError), nil, nil,
+		BeaconClientPoolEndpoint{Name: "a", Tag: EndpointTagRecent, Client: a},
+		BeaconClientPoolEndpoint{Name: "b", Tag: EndpointTagRecent, Client: b},
+	)
+
+	a.EXPECT().NodeVersion(ctx).Once().Return("", errors.New("timeout"))
+	b.EXPECT().NodeVersion(ctx).Once().Return("b-version", nil)
+	out, err := pool.NodeVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "b-version", out)
+}
+
+func TestBeaconClientPool_BreakerOpensAfterRepeatedFailures(t *testing.T) {
+	ctx := context.Background()
+	a := mocks.NewBeaconClient(t)
+	b := mocks.NewBeaconClient(t)
+
+	pool := NewBeaconClientPool(testlog.Logger(t, slog.LevelError), nil, nil,
+		BeaconClientPoolEndpoint{Name: "a", Tag: EndpointTagRecent, Client: a},
+		BeaconClientPoolEndpoint{Name: "b", Tag: EndpointTagRecent, Client: b},
+	)
+
+	// drive endpoint a's breaker open with a run of failures
+	a.EXPECT().NodeVersion(ctx).Times(breakerMinSamples).Return("", errors.New("down"))
+	b.EXPECT().NodeVersion(ctx).Times(breakerMinSamples).Return("b-version", nil)
+	for i := 0; i < breakerMinSamples; i++ {
+		out, err := pool.NodeVersion(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "b-version", out)
+	}
+
+	require.Equal(t, breakerOpen, pool.endpoints[0].state)
+
+	// while open, a is skipped entirely and only b is called
+	b.EXPECT().NodeVersion(ctx).Once().Return("b-version", nil)
+	out, err := pool.NodeVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "b-version", out)
+}
+
+func TestBeaconClientPool_HalfOpenGrantsOnlyOneProbe(t *testing.T) {
+	ep := newPoolEndpoint("a", EndpointTagRecent, nil)
+	ep.state = breakerOpen
+	ep.openedAt = time.Now().Add(-time.Hour)
+	ep.cooldown = time.Second
+
+	now := time.Now()
+	require.True(t, ep.available(now), "the first caller after cooldown should win the probe")
+	require.Equal(t, breakerHalfOpen, ep.state)
+	require.False(t, ep.available(now), "concurrent callers must not also probe")
+	require.False(t, ep.available(now), "concurrent callers must not also probe")
+}
+
+func TestBeaconClientPool_HalfOpenProbeSuccessCloses(t *testing.T) {
+	ep := newPoolEndpoint("a", EndpointTagRecent, nil)
+	ep.state = breakerOpen
+	ep.openedAt = time.Now().Add(-time.Hour)
+	ep.cooldown = 4 * time.Second
+
+	require.True(t, ep.available(time.Now()))
+	state := ep.recordResult(true)
+	require.Equal(t, breakerClosed, state)
+	require.Equal(t, time.Duration(0), ep.cooldown)
+	require.True(t, ep.available(time.Now()), "a closed breaker should be available again")
+}
+
+func TestBeaconClientPool_HalfOpenProbeFailureReopensWithDoubledCooldown(t *testing.T) {
+	ep := newPoolEndpoint("a", EndpointTagRecent, nil)
+	ep.state = breakerOpen
+	ep.openedAt = time.Now().Add(-time.Hour)
+	ep.cooldown = 4 * time.Second
+
+	require.True(t, ep.available(time.Now()))
+	state := ep.recordResult(false)
+	require.Equal(t, breakerOpen, state)
+	require.Equal(t, 8*time.Second, ep.cooldown)
+
+	// cooldown has not elapsed yet, so the endpoint must not be available
+	require.False(t, ep.available(time.Now()))
+
+	// once the (doubled) cooldown elapses, exactly one probe is granted again
+	afterCooldown := ep.openedAt.Add(ep.cooldown).Add(time.Millisecond)
+	require.True(t, ep.available(afterCooldown))
+	require.False(t, ep.available(afterCooldown))
+}
+
+func TestBeaconClientPool_BreakerCooldownDoublesUpToMax(t *testing.T) {
+	ep := newPoolEndpoint("a", EndpointTagRecent, nil)
+	ep.state = breakerOpen
+	ep.cooldown = breakerMaxCooldown
+
+	ep.open()
+	require.Equal(t, breakerMaxCooldown, ep.cooldown, "cooldown must not exceed breakerMaxCooldown")
+}
+
+func TestBeaconClientPool_NoAvailableEndpoints(t *testing.T) {
+	ctx := context.Background()
+	pool := NewBeaconClientPool(testlog.Logger(t, slog.LevelError), nil, nil)
+	_, err := pool.NodeVersion(ctx)
+	require.Error(t, err)
+}
+
+func TestBeaconClientPool_PrefersArchiveBeyondRetentionHorizon(t *testing.T) {
+	ctx := context.Background()
+	recent := mocks.NewBeaconClient(t)
+	archive := mocks.NewBeaconClient(t)
+
+	retentionHorizon := func(slot uint64) bool { return slot < 100 }
+	pool := NewBeaconClientPool(testlog.Logger(t, slog.LevelError), nil, retentionHorizon,
+		BeaconClientPoolEndpoint{Name: "recent", Tag: EndpointTagRecent, Client: recent},
+		BeaconClientPoolEndpoint{Name: "archive", Tag: EndpointTagArchive, Client: archive},
+	)
+
+	// slot 50 is older than the retention horizon: archive should be tried first and satisfy the call
+	res := eth.APIGetBlobSidecarsResponse{}
+	archive.EXPECT().BeaconBlobSideCars(ctx, false, uint64(50), []eth.IndexedBlobHash(nil)).Once().Return(res, nil)
+	_, err := pool.BeaconBlobSideCars(ctx, false, 50, nil)
+	require.NoError(t, err)
+}
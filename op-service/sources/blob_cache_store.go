@@ -0,0 +1,113 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BlobCacheStore is the persistence backend used by CachingBlobSource. The default implementation,
+// FileBlobCacheStore, is a plain directory of files, but the interface is kept narrow so a KV store
+// such as pebble or badger can be dropped in instead.
+type BlobCacheStore interface {
+	// Get returns the raw bytes stored for key, or ok=false if no entry exists.
+	Get(key string) (data []byte, ok bool, err error)
+	// Put atomically stores data under key, overwriting any existing entry.
+	Put(key string, data []byte) error
+	// Delete removes the entry for key, if any. It is not an error to delete a missing key.
+	Delete(key string) error
+	// List enumerates every entry currently persisted in the store, so a cache wrapper can rebuild its
+	// in-memory bookkeeping (LRU order and size accounting) after a restart.
+	List() ([]BlobCacheEntry, error)
+}
+
+// BlobCacheEntry describes one entry found by a BlobCacheStore.List scan, without reading its contents.
+type BlobCacheEntry struct {
+	Key  string
+	Size int64
+	// ModTime is used as a proxy for recency when seeding LRU order, since the store doesn't track
+	// last-access time separately from last-write time.
+	ModTime time.Time
+}
+
+// FileBlobCacheStore is a BlobCacheStore backed by a flat directory of files, one per key. Writes are
+// made atomic by writing to a temp file in the same directory and renaming it into place, so a crash
+// mid-write can never leave a corrupt entry for a reader to pick up.
+type FileBlobCacheStore struct {
+	dir string
+}
+
+func NewFileBlobCacheStore(dir string) (*FileBlobCacheStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob cache dir: %w", err)
+	}
+	return &FileBlobCacheStore{dir: dir}, nil
+}
+
+func (s *FileBlobCacheStore) path(key string) string {
+	return filepath.Join(s.dir, key)
+}
+
+func (s *FileBlobCacheStore) Get(key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (s *FileBlobCacheStore) Put(key string, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for blob cache entry: %w", err)
+	}
+	tmpName := tmp.Name()
+	// if we return before the rename below, make sure the temp file doesn't linger
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob cache entry: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync blob cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close blob cache entry: %w", err)
+	}
+	if err := os.Rename(tmpName, s.path(key)); err != nil {
+		return fmt.Errorf("failed to install blob cache entry: %w", err)
+	}
+	return nil
+}
+
+func (s *FileBlobCacheStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileBlobCacheStore) List() ([]BlobCacheEntry, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blob cache dir: %w", err)
+	}
+	entries := make([]BlobCacheEntry, 0, len(dirEntries))
+	for _, de := range dirEntries {
+		if de.IsDir() || strings.HasPrefix(de.Name(), ".tmp-") {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat blob cache entry %q: %w", de.Name(), err)
+		}
+		entries = append(entries, BlobCacheEntry{Key: de.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return entries, nil
+}
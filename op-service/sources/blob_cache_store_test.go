@@ -0,0 +1,88 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBlobCacheStore_GetMissingKeyIsNotAnError(t *testing.T) {
+	store, err := NewFileBlobCacheStore(t.TempDir())
+	require.NoError(t, err)
+
+	data, ok, err := store.Get("missing")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, data)
+}
+
+func TestFileBlobCacheStore_PutThenGet(t *testing.T) {
+	store, err := NewFileBlobCacheStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("a", []byte("first")))
+	data, ok, err := store.Get("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("first"), data)
+
+	// Put overwrites an existing entry rather than erroring
+	require.NoError(t, store.Put("a", []byte("second")))
+	data, ok, err = store.Get("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("second"), data)
+}
+
+func TestFileBlobCacheStore_PutLeavesNoTempFileBehind(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileBlobCacheStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("a", []byte("data")))
+
+	dirEntries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, dirEntries, 1, "the temp file used for the atomic write should have been renamed away, not left behind")
+	require.Equal(t, "a", dirEntries[0].Name())
+}
+
+func TestFileBlobCacheStore_Delete(t *testing.T) {
+	store, err := NewFileBlobCacheStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("a", []byte("data")))
+	require.NoError(t, store.Delete("a"))
+
+	_, ok, err := store.Get("a")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// deleting an already-missing key is not an error
+	require.NoError(t, store.Delete("a"))
+}
+
+func TestFileBlobCacheStore_ListExcludesTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileBlobCacheStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("a", []byte("1")))
+	require.NoError(t, store.Put("b", []byte("22")))
+
+	// simulate a crash mid-write: a leftover temp file from Put should never be listed as a real entry
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tmp-stale"), []byte("partial"), 0o644))
+
+	entries, err := store.List()
+	require.NoError(t, err)
+
+	byKey := make(map[string]BlobCacheEntry, len(entries))
+	for _, e := range entries {
+		byKey[e.Key] = e
+	}
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(1), byKey["a"].Size)
+	require.Equal(t, int64(2), byKey["b"].Size)
+}
@@ -0,0 +1,45 @@
+package sources
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusBlobsMetrics is the default BlobsMetrics implementation, recording the duration of the KZG
+// verification step in blobsFromSidecars, bucketed by the number of blobs verified in the call.
+type PrometheusBlobsMetrics struct {
+	verificationTime *prometheus.HistogramVec
+}
+
+func NewPrometheusBlobsMetrics(ns string, registry *prometheus.Registry) *PrometheusBlobsMetrics {
+	factory := promauto.With(prometheus.WrapRegistererWithPrefix(ns+"_", registry))
+	return &PrometheusBlobsMetrics{
+		verificationTime: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "blob_verification_duration_seconds",
+			Help:    "Duration of KZG proof verification in blobsFromSidecars, by blob count",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"blob_count"}),
+	}
+}
+
+func (m *PrometheusBlobsMetrics) RecordBlobVerificationTime(blobCount int, duration time.Duration) {
+	m.verificationTime.WithLabelValues(blobCountLabel(blobCount)).Observe(duration.Seconds())
+}
+
+// blobCountLabel buckets blob counts into a small, stable set of label values to keep cardinality low.
+func blobCountLabel(blobCount int) string {
+	switch {
+	case blobCount <= 0:
+		return "0"
+	case blobCount == 1:
+		return "1"
+	case blobCount <= 3:
+		return "2-3"
+	case blobCount <= 6:
+		return "4-6"
+	default:
+		return "7+"
+	}
+}
@@ -0,0 +1,273 @@
+package sources
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// L1BlobSource retrieves EIP-4844 blobs for a set of versioned hashes referenced from a given L1 block.
+type L1BlobSource interface {
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
+// ErrCacheMiss is returned by a cache-only CachingBlobSource instead of falling through to the network.
+var ErrCacheMiss = fmt.Errorf("blob cache: miss, and cache-only mode forbids fetching from the network")
+
+// CachingBlobSourceMetrics is implemented by the metrics collector passed to NewCachingBlobSource.
+type CachingBlobSourceMetrics interface {
+	RecordBlobCacheHit()
+	RecordBlobCacheMiss()
+	RecordBlobCacheEviction()
+}
+
+type NoopCachingBlobSourceMetrics struct{}
+
+func (NoopCachingBlobSourceMetrics) RecordBlobCacheHit()      {}
+func (NoopCachingBlobSourceMetrics) RecordBlobCacheMiss()     {}
+func (NoopCachingBlobSourceMetrics) RecordBlobCacheEviction() {}
+
+// cacheEntry is the gob-encoded payload persisted per blob. The commitment and proof are kept alongside
+// the blob itself so a cache hit can be re-verified on load without needing to recompute a KZG proof.
+type cacheEntry struct {
+	Blob       kzg4844.Blob
+	Commitment kzg4844.Commitment
+	Proof      kzg4844.Proof
+}
+
+// CachingBlobSource wraps an L1BlobSource, persisting retrieved blobs keyed by (slot, versioned hash) in a
+// BlobCacheStore and serving cache hits without going back to the CL. Cache bookkeeping (LRU order and the
+// size budget) is rebuilt from the store on construction, so the size budget keeps being enforced across
+// restarts instead of only against blobs written since the process started.
+type CachingBlobSource struct {
+	log     log.Logger
+	inner   L1BlobSource
+	store   BlobCacheStore
+	metrics CachingBlobSourceMetrics
+
+	maxSizeBytes int64
+	cacheOnly    bool
+
+	mu        sync.Mutex
+	lru       *list.List // front = most recently used
+	index     map[string]*list.Element
+	sizeBytes int64
+}
+
+type lruEntry struct {
+	key  string
+	size int64
+}
+
+func NewCachingBlobSource(l log.Logger, inner L1BlobSource, store BlobCacheStore, maxSizeBytes int64, cacheOnly bool, metrics CachingBlobSourceMetrics) *CachingBlobSource {
+	if metrics == nil {
+		metrics = NoopCachingBlobSourceMetrics{}
+	}
+	s := &CachingBlobSource{
+		log:          l,
+		inner:        inner,
+		store:        store,
+		metrics:      metrics,
+		maxSizeBytes: maxSizeBytes,
+		cacheOnly:    cacheOnly,
+		lru:          list.New(),
+		index:        make(map[string]*list.Element),
+	}
+	s.seed()
+	return s
+}
+
+// seed rebuilds the in-memory LRU order and size accounting from whatever is already in the store. A
+// scan failure is logged and otherwise ignored, leaving the index cold exactly as before this method
+// existed, rather than failing construction over what is ultimately just a bookkeeping gap.
+func (s *CachingBlobSource) seed() {
+	entries, err := s.store.List()
+	if err != nil {
+		s.log.Warn("failed to scan blob cache store on startup, starting with a cold index", "err", err)
+		return
+	}
+	// oldest (by mtime, our closest proxy for recency) first, so the final PushFront order is newest-first
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ModTime.Before(entries[j].ModTime) })
+	for _, e := range entries {
+		s.touch(e.Key, e.Size, true)
+	}
+}
+
+// blobCacheKey derives the on-disk/KV key for a blob referenced from the L1 block with the given
+// timestamp (used as a coarse slot proxy to keep entries grouped by L1 block) and versioned hash.
+func blobCacheKey(l1Time uint64, hash common.Hash) string {
+	return fmt.Sprintf("%d-%s", l1Time, hash)
+}
+
+func (s *CachingBlobSource) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	out := make([]*eth.Blob, len(hashes))
+	var missing []eth.IndexedBlobHash
+	missingIdx := make([]int, 0, len(hashes))
+
+	for i, ih := range hashes {
+		if entry, ok := s.load(ref.Time, ih); ok {
+			out[i] = entry
+			continue
+		}
+		missing = append(missing, ih)
+		missingIdx = append(missingIdx, i)
+	}
+
+	if len(missing) == 0 {
+		return out, nil
+	}
+	if s.cacheOnly {
+		return nil, ErrCacheMiss
+	}
+
+	fetched, err := s.inner.GetBlobs(ctx, ref, missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %d blobs missing from cache: %w", len(missing), err)
+	}
+	for i, blob := range fetched {
+		out[missingIdx[i]] = blob
+		s.save(ref.Time, missing[i], blob)
+	}
+	return out, nil
+}
+
+// Prefetch warms the cache for the given slot and hashes ahead of the current safe head, so the
+// derivation pipeline does not block on the CL when it later catches up to that slot.
+func (s *CachingBlobSource) Prefetch(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) error {
+	var missing []eth.IndexedBlobHash
+	for _, ih := range hashes {
+		if _, ok := s.load(ref.Time, ih); !ok {
+			missing = append(missing, ih)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	fetched, err := s.inner.GetBlobs(ctx, ref, missing)
+	if err != nil {
+		return fmt.Errorf("failed to prefetch %d blobs: %w", len(missing), err)
+	}
+	for i, blob := range fetched {
+		s.save(ref.Time, missing[i], blob)
+	}
+	return nil
+}
+
+// load returns the cached blob for ih, if present, re-verifying its KZG proof and versioned hash before
+// handing it back so a corrupted or truncated cache entry can never silently poison derivation.
+func (s *CachingBlobSource) load(l1Time uint64, ih eth.IndexedBlobHash) (*eth.Blob, bool) {
+	key := blobCacheKey(l1Time, ih.Hash)
+	raw, ok, err := s.store.Get(key)
+	if err != nil {
+		s.log.Warn("failed to read blob cache entry", "key", key, "err", err)
+		return nil, false
+	}
+	if !ok {
+		s.touch(key, 0, false)
+		s.metrics.RecordBlobCacheMiss()
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&entry); err != nil {
+		s.log.Warn("failed to decode blob cache entry, treating as a miss", "key", key, "err", err)
+		_ = s.store.Delete(key)
+		s.metrics.RecordBlobCacheMiss()
+		return nil, false
+	}
+	if eth.KZGToVersionedHash(entry.Commitment) != ih.Hash {
+		s.log.Warn("blob cache entry hash mismatch, evicting corrupt entry", "key", key)
+		_ = s.store.Delete(key)
+		s.metrics.RecordBlobCacheMiss()
+		return nil, false
+	}
+	if err := kzg4844.VerifyBlobProof(entry.Blob, entry.Commitment, entry.Proof); err != nil {
+		s.log.Warn("blob cache entry failed proof verification, evicting corrupt entry", "key", key, "err", err)
+		_ = s.store.Delete(key)
+		s.metrics.RecordBlobCacheMiss()
+		return nil, false
+	}
+
+	s.touch(key, int64(len(raw)), true)
+	s.metrics.RecordBlobCacheHit()
+	blob := eth.Blob(entry.Blob)
+	return &blob, true
+}
+
+// save persists a freshly fetched blob into the cache.
+func (s *CachingBlobSource) save(l1Time uint64, ih eth.IndexedBlobHash, blob *eth.Blob) {
+	if blob == nil {
+		return
+	}
+	commitment, err := kzg4844.BlobToCommitment(kzg4844.Blob(*blob))
+	if err != nil {
+		s.log.Warn("failed to compute commitment for blob cache entry, skipping cache write", "err", err)
+		return
+	}
+	proof, err := kzg4844.ComputeBlobProof(kzg4844.Blob(*blob), commitment)
+	if err != nil {
+		s.log.Warn("failed to compute proof for blob cache entry, skipping cache write", "err", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	entry := cacheEntry{Blob: kzg4844.Blob(*blob), Commitment: commitment, Proof: proof}
+	if err := gob.NewEncoder(&buf).Encode(entry); err != nil {
+		s.log.Warn("failed to encode blob cache entry, skipping cache write", "err", err)
+		return
+	}
+
+	key := blobCacheKey(l1Time, ih.Hash)
+	if err := s.store.Put(key, buf.Bytes()); err != nil {
+		s.log.Warn("failed to write blob cache entry", "key", key, "err", err)
+		return
+	}
+	s.touch(key, int64(buf.Len()), true)
+}
+
+// touch records key as the most recently used entry of the given size, evicting the least recently
+// used entries until the running total is back under the configured size budget. present indicates
+// whether the entry actually exists in the store; when it is false, any index entry for key is dropped
+// and nothing new is recorded.
+func (s *CachingBlobSource) touch(key string, size int64, present bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.index[key]; ok {
+		s.sizeBytes -= el.Value.(*lruEntry).size
+		s.lru.Remove(el)
+		delete(s.index, key)
+	}
+	if !present {
+		return
+	}
+
+	el := s.lru.PushFront(&lruEntry{key: key, size: size})
+	s.index[key] = el
+	s.sizeBytes += size
+
+	for s.maxSizeBytes > 0 && s.sizeBytes > s.maxSizeBytes && s.lru.Len() > 0 {
+		oldest := s.lru.Back()
+		oe := oldest.Value.(*lruEntry)
+		if oe.key == key {
+			// never evict the entry we just inserted; bail rather than spin
+			break
+		}
+		if err := s.store.Delete(oe.key); err != nil {
+			s.log.Warn("failed to evict blob cache entry", "key", oe.key, "err", err)
+		}
+		s.lru.Remove(oldest)
+		delete(s.index, oe.key)
+		s.sizeBytes -= oe.size
+		s.metrics.RecordBlobCacheEviction()
+	}
+}
@@ -0,0 +1,34 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestBlobCacheConfig_WrapIfEnabled(t *testing.T) {
+	logger := testlog.Logger(t, slog.LevelError)
+	inner := &stubBlobSource{}
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := &BlobCacheConfig{Enabled: false}
+		src, err := cfg.WrapIfEnabled(logger, inner, nil)
+		require.NoError(t, err)
+		require.Same(t, inner, src, "a disabled cache should return inner unwrapped")
+	})
+
+	t.Run("EnabledWithoutPath", func(t *testing.T) {
+		cfg := &BlobCacheConfig{Enabled: true}
+		_, err := cfg.WrapIfEnabled(logger, inner, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("EnabledWithPath", func(t *testing.T) {
+		cfg := &BlobCacheConfig{Enabled: true, Path: t.TempDir()}
+		src, err := cfg.WrapIfEnabled(logger, inner, nil)
+		require.NoError(t, err)
+		require.IsType(t, &CachingBlobSource{}, src)
+	})
+}
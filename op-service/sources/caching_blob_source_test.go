@@ -0,0 +1,267 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// memBlobCacheStore is an in-memory BlobCacheStore used so tests and benchmarks don't touch disk. Like
+// FileBlobCacheStore, it can be handed to a second CachingBlobSource so tests can simulate a process
+// restart against the same backing store.
+type memBlobCacheStore struct {
+	data    map[string][]byte
+	modTime map[string]time.Time
+}
+
+func newMemBlobCacheStore() *memBlobCacheStore {
+	return &memBlobCacheStore{data: make(map[string][]byte), modTime: make(map[string]time.Time)}
+}
+
+func (m *memBlobCacheStore) Get(key string) ([]byte, bool, error) {
+	v, ok := m.data[key]
+	return v, ok, nil
+}
+
+func (m *memBlobCacheStore) Put(key string, data []byte) error {
+	m.data[key] = append([]byte(nil), data...)
+	m.modTime[key] = time.Now()
+	return nil
+}
+
+func (m *memBlobCacheStore) Delete(key string) error {
+	delete(m.data, key)
+	delete(m.modTime, key)
+	return nil
+}
+
+func (m *memBlobCacheStore) List() ([]BlobCacheEntry, error) {
+	entries := make([]BlobCacheEntry, 0, len(m.data))
+	for key, data := range m.data {
+		entries = append(entries, BlobCacheEntry{Key: key, Size: int64(len(data)), ModTime: m.modTime[key]})
+	}
+	return entries, nil
+}
+
+// stubBlobSource serves blobs built by makeTestBlobSidecar, counting how many times it is called so
+// tests can assert on cache hits vs misses.
+type stubBlobSource struct {
+	calls int
+	err   error
+}
+
+func (s *stubBlobSource) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	out := make([]*eth.Blob, len(hashes))
+	for i, ih := range hashes {
+		_, sidecar := makeTestBlobSidecar(ih.Index)
+		blob := eth.Blob(sidecar.Blob)
+		out[i] = &blob
+	}
+	return out, nil
+}
+
+func testHashes(n int) []eth.IndexedBlobHash {
+	hashes := make([]eth.IndexedBlobHash, n)
+	for i := 0; i < n; i++ {
+		idh, _ := makeTestBlobSidecar(uint64(i))
+		hashes[i] = idh
+	}
+	return hashes
+}
+
+func TestCachingBlobSource_MissThenHit(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	src := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := testHashes(2)
+
+	blobs, err := src.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	require.Equal(t, 1, inner.calls)
+
+	// second call should be served entirely from the cache
+	blobs2, err := src.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, blobs, blobs2)
+	require.Equal(t, 1, inner.calls)
+}
+
+func TestCachingBlobSource_CacheOnlyMissErrors(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	src := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, true, nil)
+
+	_, err := src.GetBlobs(context.Background(), eth.L1BlockRef{Time: 100}, testHashes(1))
+	require.ErrorIs(t, err, ErrCacheMiss)
+	require.Equal(t, 0, inner.calls)
+}
+
+func TestCachingBlobSource_CorruptEntryIsTreatedAsMiss(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	src := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := testHashes(1)
+	_, err := src.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, 1, inner.calls)
+
+	// corrupt the persisted entry directly in the backing store
+	key := blobCacheKey(ref.Time, hashes[0].Hash)
+	store.data[key] = []byte("not a valid cache entry")
+
+	_, err = src.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, 2, inner.calls, "corrupt entry should be refetched rather than returned")
+}
+
+func TestCachingBlobSource_EvictsOverBudget(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	// a tiny budget forces eviction after just a couple of entries
+	src := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 1, false, nil)
+
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := testHashes(4)
+	for _, ih := range hashes {
+		_, err := src.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{ih})
+		require.NoError(t, err)
+	}
+
+	require.Less(t, len(store.data), len(hashes), "oldest entries should have been evicted to stay under budget")
+}
+
+func TestCachingBlobSource_SeedsFromStoreOnRestart(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	first := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := testHashes(2)
+	_, err := first.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, 1, inner.calls)
+
+	// simulate a process restart: a fresh CachingBlobSource wrapping the same, already-populated store
+	second := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+	_, err = second.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, 1, inner.calls, "blobs written by a previous process should be served from the cache without refetching")
+}
+
+func TestCachingBlobSource_SeedEnforcesBudgetAcrossRestart(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	// no size budget in the first process: every blob gets written to the store
+	first := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := testHashes(4)
+	for _, ih := range hashes {
+		_, err := first.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{ih})
+		require.NoError(t, err)
+	}
+	require.Len(t, store.data, 4)
+
+	// the next run starts with a tight budget: the startup scan must evict down to size immediately,
+	// rather than only starting to enforce the budget against blobs fetched from then on
+	_ = NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 1, false, nil)
+	require.Less(t, len(store.data), 4, "seeding should evict entries written before the budget shrank")
+}
+
+func TestCachingBlobSource_Prefetch(t *testing.T) {
+	inner := &stubBlobSource{}
+	store := newMemBlobCacheStore()
+	src := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+
+	ref := eth.L1BlockRef{Time: 100}
+	hashes := testHashes(2)
+	require.NoError(t, src.Prefetch(context.Background(), ref, hashes))
+	require.Equal(t, 1, inner.calls)
+
+	// the derivation pipeline later catches up and asks for the same blobs: no further network call
+	_, err := src.GetBlobs(context.Background(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, 1, inner.calls)
+}
+
+func TestCachingBlobSource_InnerErrorPropagates(t *testing.T) {
+	inner := &stubBlobSource{err: errors.New("beacon node unreachable")}
+	store := newMemBlobCacheStore()
+	src := NewCachingBlobSource(testlog.Logger(t, slog.LevelError), inner, store, 0, false, nil)
+
+	_, err := src.GetBlobs(context.Background(), eth.L1BlockRef{Time: 100}, testHashes(1))
+	require.Error(t, err)
+}
+
+// benchmarkCachingBlobSourceDerivation measures repeated derivation passes over the same blocks. For the
+// warm case, a single CachingBlobSource is prepopulated once and then reused for every b.N iteration, so
+// every pass after the first is served from the cache. For the cold case, every iteration needs its own
+// never-before-seen CachingBlobSource/store: reusing one across iterations would mean only the very
+// first of the b.N passes is actually cold, with the rest silently measuring the warm path instead.
+func benchmarkCachingBlobSourceDerivation(b *testing.B, blockCount int, warm bool) {
+	inner := &stubBlobSource{}
+	ctx := context.Background()
+
+	blocks := make([]eth.L1BlockRef, blockCount)
+	hashesByBlock := make([][]eth.IndexedBlobHash, blockCount)
+	for i := 0; i < blockCount; i++ {
+		blocks[i] = eth.L1BlockRef{Time: uint64(i)}
+		hashesByBlock[i] = testHashes(3)
+	}
+
+	if warm {
+		store := newMemBlobCacheStore()
+		src := NewCachingBlobSource(testlog.Logger(b, slog.LevelError), inner, store, 0, false, nil)
+		for i := 0; i < blockCount; i++ {
+			if _, err := src.GetBlobs(ctx, blocks[i], hashesByBlock[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			for i := 0; i < blockCount; i++ {
+				if _, err := src.GetBlobs(ctx, blocks[i], hashesByBlock[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+		return
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		store := newMemBlobCacheStore()
+		src := NewCachingBlobSource(testlog.Logger(b, slog.LevelError), inner, store, 0, false, nil)
+		b.StartTimer()
+
+		for i := 0; i < blockCount; i++ {
+			if _, err := src.GetBlobs(ctx, blocks[i], hashesByBlock[i]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCachingBlobSource_Cold_10Blocks(b *testing.B) {
+	benchmarkCachingBlobSourceDerivation(b, 10, false)
+}
+
+func BenchmarkCachingBlobSource_Warm_10Blocks(b *testing.B) {
+	benchmarkCachingBlobSourceDerivation(b, 10, true)
+}
@@ -0,0 +1,135 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BeaconClient is a thin wrapper over the Beacon API, for blob sidecar retrieval.
+type BeaconClient interface {
+	// NodeVersion returns the version of the beacon node, useful for identifying the endpoint behind a
+	// load-balancer or a pool of fallback endpoints.
+	NodeVersion(ctx context.Context) (string, error)
+
+	// BeaconBlobSideCars fetches the blob sidecars for the given slot. If fetchAllSidecars is true, all sidecars
+	// for the slot are returned, otherwise only the sidecars matching hashes are returned.
+	BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error)
+}
+
+// FallbackBeaconClient is a BeaconClient that falls back to a secondary endpoint if the primary fails.
+// It pre-dates BeaconClientPool and is kept around as a convenient two-endpoint special case.
+type FallbackBeaconClient struct {
+	primary   BeaconClient
+	secondary BeaconClient
+	l         log.Logger
+}
+
+func NewFallbackBeaconClient(primary BeaconClient, secondary BeaconClient, l log.Logger) *FallbackBeaconClient {
+	return &FallbackBeaconClient{primary: primary, secondary: secondary, l: l}
+}
+
+func (b *FallbackBeaconClient) NodeVersion(ctx context.Context) (string, error) {
+	v, err := b.primary.NodeVersion(ctx)
+	if err == nil {
+		return v, nil
+	}
+	b.l.Warn("beacon client primary request failed, trying secondary", "err", err)
+	return b.secondary.NodeVersion(ctx)
+}
+
+func (b *FallbackBeaconClient) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	res, err := b.primary.BeaconBlobSideCars(ctx, fetchAllSidecars, slot, hashes)
+	if err == nil {
+		return res, nil
+	}
+	b.l.Warn("beacon client primary request failed, trying secondary", "err", err)
+	return b.secondary.BeaconBlobSideCars(ctx, fetchAllSidecars, slot, hashes)
+}
+
+// BlobsMetrics receives timing for the KZG verification step of blobsFromSidecars.
+type BlobsMetrics interface {
+	RecordBlobVerificationTime(blobCount int, duration time.Duration)
+}
+
+// blobsFromSidecars returns the blobs corresponding to hashes, in the order hashes are given, pulling matching
+// indices out of sidecars and verifying the KZG commitment and proof of every sidecar along the way.
+//
+// Proof verification for a multi-blob batch is run concurrently across the blobs, since a full 6-blob
+// sidecar batch is considerably faster to verify this way than strictly one-by-one. This is a
+// concurrency speedup only: it still performs one pairing check per blob, not the single aggregated
+// pairing check across the whole batch that a true batched verification API would allow. See
+// verifyBlobProofs. metrics may be nil.
+func blobsFromSidecars(sidecars []*eth.BlobSidecar, hashes []eth.IndexedBlobHash, metrics BlobsMetrics) ([]eth.Blob, error) {
+	if len(sidecars) != len(hashes) {
+		return nil, fmt.Errorf("expected %v sidecars but got %v", len(hashes), len(sidecars))
+	}
+
+	out := make([]eth.Blob, len(hashes))
+	blobs := make([]kzg4844.Blob, len(hashes))
+	commitments := make([]kzg4844.Commitment, len(hashes))
+	proofs := make([]kzg4844.Proof, len(hashes))
+
+	for i, ih := range hashes {
+		sidecar := sidecars[i]
+		if sidecar.Index != eth.Uint64String(ih.Index) {
+			return nil, fmt.Errorf("expected sidecar %d to have index %d but got %d", i, ih.Index, sidecar.Index)
+		}
+
+		// make sure the blob's kzg commitment hashes to the expected value
+		hash := eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment))
+		if hash != ih.Hash {
+			return nil, fmt.Errorf("expected hash %s for blob at index %d but got %s", ih.Hash, i, hash)
+		}
+
+		out[i] = sidecar.Blob
+		blobs[i] = kzg4844.Blob(sidecar.Blob)
+		commitments[i] = kzg4844.Commitment(sidecar.KZGCommitment)
+		proofs[i] = kzg4844.Proof(sidecar.KZGProof)
+	}
+
+	if err := verifyBlobProofs(blobs, commitments, proofs, metrics); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// verifyBlobProofs verifies blobs against their commitments and proofs. go-ethereum's kzg4844 package
+// exposes no batched pairing check, so the speedup for a multi-blob batch instead comes from running the
+// (CPU-bound) per-blob checks concurrently rather than strictly one-by-one. The benchmarks in
+// l1_beacon_client_test.go measure that concurrency speedup, not a reduction in pairing-check count —
+// don't read them as evidence the original single-pairing-check batching ask was met.
+func verifyBlobProofs(blobs []kzg4844.Blob, commitments []kzg4844.Commitment, proofs []kzg4844.Proof, metrics BlobsMetrics) error {
+	start := time.Now()
+	errs := make([]error, len(blobs))
+	if len(blobs) <= 1 {
+		for i := range blobs {
+			errs[i] = kzg4844.VerifyBlobProof(blobs[i], commitments[i], proofs[i])
+		}
+	} else {
+		var wg sync.WaitGroup
+		wg.Add(len(blobs))
+		for i := range blobs {
+			i := i
+			go func() {
+				defer wg.Done()
+				errs[i] = kzg4844.VerifyBlobProof(blobs[i], commitments[i], proofs[i])
+			}()
+		}
+		wg.Wait()
+	}
+	if metrics != nil {
+		metrics.RecordBlobVerificationTime(len(blobs), time.Since(start))
+	}
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("blob at index %d failed verification: %w", i, err)
+		}
+	}
+	return nil
+}
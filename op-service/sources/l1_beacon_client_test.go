@@ -48,17 +48,17 @@ func TestBlobsFromSidecars(t *testing.T) {
 
 	// put the sidecars in scrambled order to confirm error
 	sidecars := []*eth.BlobSidecar{sidecar2, sidecar0, sidecar1}
-	_, err := blobsFromSidecars(sidecars, hashes)
+	_, err := blobsFromSidecars(sidecars, hashes, nil)
 	require.Error(t, err)
 
 	// too few sidecars should error
 	sidecars = []*eth.BlobSidecar{sidecar0, sidecar1}
-	_, err = blobsFromSidecars(sidecars, hashes)
+	_, err = blobsFromSidecars(sidecars, hashes, nil)
 	require.Error(t, err)
 
 	// correct order should work
 	sidecars = []*eth.BlobSidecar{sidecar0, sidecar1, sidecar2}
-	blobs, err := blobsFromSidecars(sidecars, hashes)
+	blobs, err := blobsFromSidecars(sidecars, hashes, nil)
 	require.NoError(t, err)
 	// confirm order by checking first blob byte against expected index
 	for i := range blobs {
@@ -69,31 +69,52 @@ func TestBlobsFromSidecars(t *testing.T) {
 	badProof := *sidecar0
 	badProof.KZGProof[11]++
 	sidecars[1] = &badProof
-	_, err = blobsFromSidecars(sidecars, hashes)
+	_, err = blobsFromSidecars(sidecars, hashes, nil)
 	require.Error(t, err)
 
 	// mangle a commitment to make sure it's detected
 	badCommitment := *sidecar0
 	badCommitment.KZGCommitment[13]++
 	sidecars[1] = &badCommitment
-	_, err = blobsFromSidecars(sidecars, hashes)
+	_, err = blobsFromSidecars(sidecars, hashes, nil)
 	require.Error(t, err)
 
 	// mangle a hash to make sure it's detected
 	sidecars[1] = sidecar0
 	hashes[2].Hash[17]++
-	_, err = blobsFromSidecars(sidecars, hashes)
+	_, err = blobsFromSidecars(sidecars, hashes, nil)
 	require.Error(t, err)
 }
 
 func TestBlobsFromSidecars_EmptySidecarList(t *testing.T) {
 	hashes := []eth.IndexedBlobHash{}
 	sidecars := []*eth.BlobSidecar{}
-	blobs, err := blobsFromSidecars(sidecars, hashes)
+	blobs, err := blobsFromSidecars(sidecars, hashes, nil)
 	require.NoError(t, err)
 	require.Empty(t, blobs, "blobs should be empty when no sidecars are provided")
 }
 
+func benchmarkBlobsFromSidecars(b *testing.B, count int) {
+	hashes := make([]eth.IndexedBlobHash, count)
+	sidecars := make([]*eth.BlobSidecar, count)
+	for i := 0; i < count; i++ {
+		idh, sidecar := makeTestBlobSidecar(uint64(i))
+		hashes[i] = idh
+		sidecars[i] = sidecar
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := blobsFromSidecars(sidecars, hashes, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlobsFromSidecars_1(b *testing.B) { benchmarkBlobsFromSidecars(b, 1) }
+func BenchmarkBlobsFromSidecars_3(b *testing.B) { benchmarkBlobsFromSidecars(b, 3) }
+func BenchmarkBlobsFromSidecars_6(b *testing.B) { benchmarkBlobsFromSidecars(b, 6) }
+
 func TestFallbackClient(t *testing.T) {
 	ctx := context.Background()
 	a := mocks.NewBeaconClient(t)
@@ -50,6 +50,24 @@ func (src SyncSource) RefByL2Hash(ctx context.Context, l2Hash common.Hash, genes
 	return derive.BlockReferences(refL2Block, genesis)
 }
 
+// RefByL2HashWithFork is like RefByL2Hash, but additionally reports the L1 block that is currently
+// canonical at refL1's height, as l1Fork. It does so by re-fetching L1 by number and comparing the
+// result against refL1: if the hashes match, refL1 is still canonical and l1Fork equals refL1; if they
+// differ, the L2 block was derived from an L1 block that has since been reorged out, and l1Fork is the
+// block that has taken its place.
+func (src SyncSource) RefByL2HashWithFork(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (refL1 eth.BlockID, refL2 eth.BlockID, parentL2 common.Hash, l1Fork eth.BlockID, err error) {
+	refL1, refL2, parentL2, err = src.RefByL2Hash(ctx, l2Hash, genesis)
+	if err != nil {
+		return
+	}
+	l1Fork, _, err = src.L1.BlockLinkByNumber(ctx, refL1.Number)
+	if err != nil {
+		err = fmt.Errorf("failed to check L1 canonicalness of block %s: %v", refL1, err)
+		return
+	}
+	return
+}
+
 // SyncReference helps inform the sync algorithm of the L2 sync-state and L1 canonical chain
 type SyncReference interface {
 	// RefByL1Num fetches the canonical L1 block hash and the parent for the given L1 block height.
@@ -61,4 +79,8 @@ type SyncReference interface {
 
 	// RefByL2Hash fetches the L1 and L2 block IDs from the engine for the given L2 block hash.
 	RefByL2Hash(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (refL1 eth.BlockID, refL2 eth.BlockID, parentL2 common.Hash, err error)
+
+	// RefByL2HashWithFork is like RefByL2Hash, but additionally returns the L1 block currently canonical
+	// at refL1's height, so the caller can tell whether refL1 itself has since been reorged out.
+	RefByL2HashWithFork(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (refL1 eth.BlockID, refL2 eth.BlockID, parentL2 common.Hash, l1Fork eth.BlockID, err error)
 }
@@ -0,0 +1,184 @@
+package sync
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// reorgAwareSourceCacheSize is the number of (L1 height -> L1 hash) mappings a ReorgAwareSource
+// remembers, bounding both its memory use and how deep a reorg it can walk back through to find a
+// common ancestor.
+const reorgAwareSourceCacheSize = 64
+
+// ReorgDetected is emitted on ReorgAwareSource.Reorgs when an L1 block this source previously reported
+// is found to no longer be canonical.
+type ReorgDetected struct {
+	// L1Num is the L1 height at which the reorg was observed.
+	L1Num uint64
+	// Old is the previously reported, now-orphaned L1 block at L1Num.
+	Old eth.BlockID
+	// New is the L1 block now canonical at L1Num.
+	New eth.BlockID
+	// CommonAncestor is the highest cached L1 block, below L1Num, that is canonical in both chains. It is
+	// the zero BlockID if no common ancestor could be found within the cache.
+	CommonAncestor eth.BlockID
+}
+
+// ReorgAwareSource wraps a SyncReference, remembering the last reorgAwareSourceCacheSize L1 blocks it
+// has reported. Each time it reports an L1 block at a height it has seen before, it re-checks that
+// height against the inner source's view of the canonical chain: a hash mismatch means an L1 reorg has
+// happened since the block was cached, so it walks back through the cache to find the common ancestor
+// and emits a ReorgDetected event, allowing the driver to trigger re-derivation from the safe head.
+type ReorgAwareSource struct {
+	SyncReference
+
+	// Reorgs receives a ReorgDetected event for every reorg this source observes. It is buffered by one
+	// so a single pending reorg is never dropped while waiting for the driver to catch up; additional
+	// reorgs observed before that one is drained are coalesced by being skipped.
+	Reorgs chan ReorgDetected
+
+	mu    sync.Mutex
+	order *list.List // list of uint64 L1 heights, front = most recently seen
+	elems map[uint64]*list.Element
+	cache map[uint64]eth.BlockID
+}
+
+// NewReorgAwareSource wraps inner with L1 reorg detection.
+func NewReorgAwareSource(inner SyncReference) *ReorgAwareSource {
+	return &ReorgAwareSource{
+		SyncReference: inner,
+		Reorgs:        make(chan ReorgDetected, 1),
+		order:         list.New(),
+		elems:         make(map[uint64]*list.Element),
+		cache:         make(map[uint64]eth.BlockID),
+	}
+}
+
+// RefByL1Num fetches the canonical L1 block hash and the parent for the given L1 block height, and
+// checks the result against what was previously cached for that height.
+func (s *ReorgAwareSource) RefByL1Num(ctx context.Context, l1Num uint64) (self eth.BlockID, parent eth.BlockID, err error) {
+	self, parent, err = s.SyncReference.RefByL1Num(ctx, l1Num)
+	if err != nil {
+		return
+	}
+	s.observe(ctx, self)
+	return
+}
+
+// RefByL2Num fetches the L1 and L2 block IDs from the engine for the given L2 block height, and checks
+// the resulting L1 reference against what was previously cached for its height.
+func (s *ReorgAwareSource) RefByL2Num(ctx context.Context, l2Num *big.Int, genesis *rollup.Genesis) (refL1 eth.BlockID, refL2 eth.BlockID, parentL2 common.Hash, err error) {
+	refL1, refL2, parentL2, err = s.SyncReference.RefByL2Num(ctx, l2Num, genesis)
+	if err != nil {
+		return
+	}
+	s.observe(ctx, refL1)
+	return
+}
+
+// RefByL2Hash fetches the L1 and L2 block IDs from the engine for the given L2 block hash, and checks
+// the resulting L1 reference against what was previously cached for its height.
+func (s *ReorgAwareSource) RefByL2Hash(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (refL1 eth.BlockID, refL2 eth.BlockID, parentL2 common.Hash, err error) {
+	refL1, refL2, parentL2, err = s.SyncReference.RefByL2Hash(ctx, l2Hash, genesis)
+	if err != nil {
+		return
+	}
+	s.observe(ctx, refL1)
+	return
+}
+
+// RefByL2HashWithFork fetches the L1 and L2 block IDs from the engine for the given L2 block hash, and
+// checks the resulting L1 reference against what was previously cached for its height.
+func (s *ReorgAwareSource) RefByL2HashWithFork(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (refL1 eth.BlockID, refL2 eth.BlockID, parentL2 common.Hash, l1Fork eth.BlockID, err error) {
+	refL1, refL2, parentL2, l1Fork, err = s.SyncReference.RefByL2HashWithFork(ctx, l2Hash, genesis)
+	if err != nil {
+		return
+	}
+	// l1Fork, not refL1, is the inner source's current view of the canonical chain at that height.
+	s.observe(ctx, l1Fork)
+	return
+}
+
+// observe records that l1 is now the block reported at l1.Number, emitting a ReorgDetected event if a
+// previously-cached block at that height has since been reorged out.
+func (s *ReorgAwareSource) observe(ctx context.Context, l1 eth.BlockID) {
+	s.mu.Lock()
+	cached, ok := s.cache[l1.Number]
+	cachedBelow := s.cachedBelow(l1.Number)
+	s.mu.Unlock()
+
+	if ok && cached.Hash != l1.Hash {
+		// The walk-back below can make several sequential RPC calls; do it without holding s.mu so it
+		// doesn't stall unrelated lookups on this source for its duration.
+		ancestor, err := s.findCommonAncestor(ctx, cachedBelow)
+		if err != nil {
+			ancestor = eth.BlockID{}
+		}
+		select {
+		case s.Reorgs <- ReorgDetected{L1Num: l1.Number, Old: cached, New: l1, CommonAncestor: ancestor}:
+		default:
+			// a reorg notification is already pending; the driver will re-derive past this one too.
+		}
+	}
+
+	s.mu.Lock()
+	s.remember(l1)
+	s.mu.Unlock()
+}
+
+// cachedBelow returns a snapshot of the cached L1 blocks below l1Num, ordered from highest to lowest
+// height. s.mu must be held by the caller.
+func (s *ReorgAwareSource) cachedBelow(l1Num uint64) []eth.BlockID {
+	var below []eth.BlockID
+	for num, id := range s.cache {
+		if num < l1Num {
+			below = append(below, id)
+		}
+	}
+	sort.Slice(below, func(i, j int) bool { return below[i].Number > below[j].Number })
+	return below
+}
+
+// findCommonAncestor walks back through candidates, re-fetching each from the inner source, until it
+// finds one whose canonical hash still matches what was cached. That is the highest point at which the
+// old and new L1 chains agree. candidates must be ordered from highest to lowest height.
+func (s *ReorgAwareSource) findCommonAncestor(ctx context.Context, candidates []eth.BlockID) (eth.BlockID, error) {
+	for _, cached := range candidates {
+		current, _, err := s.SyncReference.RefByL1Num(ctx, cached.Number)
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to check L1 block %d while walking back to common ancestor: %w", cached.Number, err)
+		}
+		if current.Hash == cached.Hash {
+			return current, nil
+		}
+	}
+	return eth.BlockID{}, fmt.Errorf("no common ancestor found within the last %d cached L1 blocks", reorgAwareSourceCacheSize)
+}
+
+// remember records l1 as the most recently seen block at its height, evicting the least recently seen
+// entry if the cache is now over capacity.
+func (s *ReorgAwareSource) remember(l1 eth.BlockID) {
+	if elem, ok := s.elems[l1.Number]; ok {
+		s.order.MoveToFront(elem)
+	} else {
+		s.elems[l1.Number] = s.order.PushFront(l1.Number)
+	}
+	s.cache[l1.Number] = l1
+
+	for uint64(s.order.Len()) > reorgAwareSourceCacheSize {
+		back := s.order.Back()
+		num := back.Value.(uint64)
+		s.order.Remove(back)
+		delete(s.elems, num)
+		delete(s.cache, num)
+	}
+}
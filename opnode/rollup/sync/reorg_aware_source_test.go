@@ -0,0 +1,145 @@
+package sync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSyncReference is a SyncReference test double whose RefByL1Num responses can be swapped out
+// between calls, to simulate an L1 reorg observed mid-test. The L2-facing methods are unused by these
+// tests and return zero values.
+type stubSyncReference struct {
+	refByL1Num func(ctx context.Context, l1Num uint64) (eth.BlockID, eth.BlockID, error)
+}
+
+func (s *stubSyncReference) RefByL1Num(ctx context.Context, l1Num uint64) (eth.BlockID, eth.BlockID, error) {
+	return s.refByL1Num(ctx, l1Num)
+}
+
+func (s *stubSyncReference) RefByL2Num(ctx context.Context, l2Num *big.Int, genesis *rollup.Genesis) (eth.BlockID, eth.BlockID, common.Hash, error) {
+	return eth.BlockID{}, eth.BlockID{}, common.Hash{}, nil
+}
+
+func (s *stubSyncReference) RefByL2Hash(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (eth.BlockID, eth.BlockID, common.Hash, error) {
+	return eth.BlockID{}, eth.BlockID{}, common.Hash{}, nil
+}
+
+func (s *stubSyncReference) RefByL2HashWithFork(ctx context.Context, l2Hash common.Hash, genesis *rollup.Genesis) (eth.BlockID, eth.BlockID, common.Hash, eth.BlockID, error) {
+	return eth.BlockID{}, eth.BlockID{}, common.Hash{}, eth.BlockID{}, nil
+}
+
+// blockID builds a distinct eth.BlockID for height num, tagged with tag so different "chains" at the
+// same height compare unequal.
+func blockID(num uint64, tag byte) eth.BlockID {
+	var h common.Hash
+	h[31] = tag
+	return eth.BlockID{Number: num, Hash: h}
+}
+
+func TestReorgAwareSource_DetectsReorgAndFindsCommonAncestor(t *testing.T) {
+	ctx := context.Background()
+
+	// heights 8 and 9 stay canonical; height 10 is reorged from tag 0xAA to 0xBB between calls.
+	responses := map[uint64]eth.BlockID{
+		8:  blockID(8, 0x08),
+		9:  blockID(9, 0x09),
+		10: blockID(10, 0xAA),
+	}
+	inner := &stubSyncReference{
+		refByL1Num: func(ctx context.Context, l1Num uint64) (eth.BlockID, eth.BlockID, error) {
+			return responses[l1Num], eth.BlockID{}, nil
+		},
+	}
+	src := NewReorgAwareSource(inner)
+
+	for num := uint64(8); num <= 10; num++ {
+		_, _, err := src.RefByL1Num(ctx, num)
+		require.NoError(t, err)
+	}
+
+	responses[10] = blockID(10, 0xBB)
+	self, _, err := src.RefByL1Num(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, blockID(10, 0xBB), self)
+
+	select {
+	case ev := <-src.Reorgs:
+		require.Equal(t, uint64(10), ev.L1Num)
+		require.Equal(t, blockID(10, 0xAA), ev.Old)
+		require.Equal(t, blockID(10, 0xBB), ev.New)
+		require.Equal(t, blockID(9, 0x09), ev.CommonAncestor)
+	default:
+		t.Fatal("expected a ReorgDetected event")
+	}
+}
+
+func TestReorgAwareSource_NoEventWhenUnchanged(t *testing.T) {
+	ctx := context.Background()
+	inner := &stubSyncReference{
+		refByL1Num: func(ctx context.Context, l1Num uint64) (eth.BlockID, eth.BlockID, error) {
+			return blockID(l1Num, 0x01), eth.BlockID{}, nil
+		},
+	}
+	src := NewReorgAwareSource(inner)
+
+	_, _, err := src.RefByL1Num(ctx, 5)
+	require.NoError(t, err)
+	_, _, err = src.RefByL1Num(ctx, 5)
+	require.NoError(t, err)
+
+	select {
+	case ev := <-src.Reorgs:
+		t.Fatalf("unexpected reorg event: %+v", ev)
+	default:
+	}
+}
+
+func TestReorgAwareSource_CoalescesReorgsUntilDrained(t *testing.T) {
+	ctx := context.Background()
+	var tag byte = 0x01
+	inner := &stubSyncReference{
+		refByL1Num: func(ctx context.Context, l1Num uint64) (eth.BlockID, eth.BlockID, error) {
+			return blockID(l1Num, tag), eth.BlockID{}, nil
+		},
+	}
+	src := NewReorgAwareSource(inner)
+
+	_, _, err := src.RefByL1Num(ctx, 5)
+	require.NoError(t, err)
+
+	// two reorgs in a row at the same height, with nobody draining src.Reorgs in between
+	tag = 0x02
+	_, _, err = src.RefByL1Num(ctx, 5)
+	require.NoError(t, err)
+	tag = 0x03
+	_, _, err = src.RefByL1Num(ctx, 5)
+	require.NoError(t, err)
+
+	require.Len(t, src.Reorgs, 1, "a second reorg observed before the first is drained should be coalesced, not queued")
+}
+
+func TestReorgAwareSource_EvictsOldestHeightBeyondCacheSize(t *testing.T) {
+	ctx := context.Background()
+	inner := &stubSyncReference{
+		refByL1Num: func(ctx context.Context, l1Num uint64) (eth.BlockID, eth.BlockID, error) {
+			return blockID(l1Num, byte(l1Num)), eth.BlockID{}, nil
+		},
+	}
+	src := NewReorgAwareSource(inner)
+
+	for num := uint64(0); num < reorgAwareSourceCacheSize+10; num++ {
+		_, _, err := src.RefByL1Num(ctx, num)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, src.cache, reorgAwareSourceCacheSize)
+	_, ok := src.cache[0]
+	require.False(t, ok, "height 0 should have been evicted once the cache exceeded its size")
+}